@@ -5,18 +5,77 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 	_ "github.com/mattn/go-sqlite3"
 )
 
+// refreshInterval controls how often the main loop reloads tasks from the
+// DB in the background, independent of any user action.
+const refreshInterval = 5 * time.Second
+
+// initDBSQL creates the task table if it doesn't already exist.
+const initDBSQL = `
+CREATE TABLE IF NOT EXISTS task (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	task_content TEXT NOT NULL,
+	task_spoc TEXT,
+	task_state TEXT NOT NULL DEFAULT 'open',
+	task_priority TEXT NOT NULL DEFAULT 'med',
+	task_due_date TEXT,
+	created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	closed_at TEXT
+);
+
+CREATE TABLE IF NOT EXISTS task_history (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	task_id TEXT NOT NULL,
+	operation TEXT NOT NULL,
+	column_idx INTEGER,
+	prev_content TEXT,
+	prev_spoc TEXT,
+	prev_state TEXT,
+	prev_priority TEXT,
+	prev_due_date TEXT,
+	prev_closed_at TEXT,
+	prev_created_at TEXT,
+	created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+`
+
 type Task struct {
-	ID      string
-	Content string
-	SPOC    string
-	State   string
-	Created string
-	Closed  sql.NullString
+	ID       string
+	Content  string
+	SPOC     string
+	State    string
+	Priority string
+	DueDate  sql.NullString
+	Created  string
+	Closed   sql.NullString
+}
+
+// historyEntry is a reverse-operation record: enough of the task's prior
+// state to undo an edit or a delete. Column is only meaningful when
+// Operation is "edit"; PrevContent/PrevSPOC/PrevState/PrevPriority/
+// PrevDueDate/PrevClosedAt/PrevCreatedAt hold a full snapshot for "delete"
+// and just the one changed value for "edit". PrevClosedAt only accompanies
+// a Column-3 (state) edit: closed_at is derived from task_state rather than
+// edited directly, so reverting a state change must restore it alongside
+// the state itself.
+type historyEntry struct {
+	ID            int64
+	TaskID        string
+	Operation     string // "edit" or "delete"
+	Column        int
+	PrevContent   string
+	PrevSPOC      string
+	PrevState     string
+	PrevPriority  string
+	PrevDueDate   sql.NullString
+	PrevClosedAt  sql.NullString
+	PrevCreatedAt string
 }
 
 func initDB() (*sql.DB, error) {
@@ -49,6 +108,11 @@ type Stats struct {
 	avgCompletionTime float64 // in days
 }
 
+// editableCols lists the task columns (as indices into drawTaskList's
+// fields slice) that Tab cycles through while in edit mode: content, SPOC,
+// state, priority, due date. The ID column is never editable.
+var editableCols = []int{1, 2, 3, 4, 5}
+
 type App struct {
 	screen     tcell.Screen
 	db         *sql.DB
@@ -57,9 +121,35 @@ type App struct {
 	stats      Stats
 	activeView bool
 	cursor     int
-	mode       string // 'normal' or 'edit'
+	mode       string // 'normal', 'edit', 'new_task', 'confirm_delete', or 'search'
 	editBuffer string
 	editCol    int
+
+	// filterBuffer holds the incremental search query built up in search
+	// mode; while non-empty it filters both task panes.
+	filterBuffer string
+
+	// newTaskFields holds the in-progress values for the new-task modal,
+	// in the order content, SPOC, priority, due date.
+	newTaskFields   []string
+	newTaskFieldIdx int
+
+	// deleteTaskID holds the task awaiting confirmation from 'd'.
+	deleteTaskID string
+
+	// statusMsg holds the most recent validation error from the new-task
+	// or edit form, shown on the status line instead of aborting the form.
+	statusMsg string
+
+	// refreshChan carries explicit reload requests (e.g. after a save)
+	// into the Run select loop, alongside the periodic ticker.
+	refreshChan chan struct{}
+
+	// history is the undo stack, persisted to task_history so it survives
+	// a restart. redoStack only makes sense within the current run, so it
+	// isn't persisted.
+	history   []historyEntry
+	redoStack []historyEntry
 }
 
 func NewApp() (*App, error) {
@@ -79,19 +169,316 @@ func NewApp() (*App, error) {
 	}
 
 	return &App{
-		screen: screen,
-		db:     db,
-		cursor: 0,
-		mode:   "normal",
+		screen:      screen,
+		db:          db,
+		cursor:      0,
+		mode:        "normal",
+		refreshChan: make(chan struct{}, 1),
 	}, nil
 }
 
+// requestRefresh asks the Run loop to reload tasks from the DB on its next
+// iteration, without blocking the caller.
+func (app *App) requestRefresh() {
+	select {
+	case app.refreshChan <- struct{}{}:
+	default:
+	}
+}
+
+// filterTasks returns the tasks whose ID, content, or SPOC contains term,
+// case-insensitively. An empty term matches everything.
+//
+// This is a plain O(n) scan, not an FTS5 index lookup: fix 045dde1 dropped
+// the task_fts mirror because CREATE VIRTUAL TABLE ... USING fts5 crashed
+// a default `go build`/`go run` with no tooling enforcing -tags
+// sqlite_fts5. A linear scan over in-memory task/closedList is fine at the
+// hundreds-of-tasks scale this tool targets; if that stops holding, revisit
+// FTS5 with proper build tooling (Makefile/CI) rather than re-adding the
+// table unconditionally.
+func filterTasks(tasks []Task, term string) []Task {
+	if term == "" {
+		return tasks
+	}
+	term = strings.ToLower(term)
+	filtered := make([]Task, 0, len(tasks))
+	for _, t := range tasks {
+		if strings.Contains(strings.ToLower(t.ID), term) ||
+			strings.Contains(strings.ToLower(t.Content), term) ||
+			strings.Contains(strings.ToLower(t.SPOC), term) {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
+}
+
+// visibleActiveList and visibleClosedList apply the current search filter
+// to the loaded task lists; every part of the UI that indexes by cursor
+// should read through these rather than the raw lists.
+func (app *App) visibleActiveList() []Task {
+	return filterTasks(app.activeList, app.filterBuffer)
+}
+
+func (app *App) visibleClosedList() []Task {
+	return filterTasks(app.closedList, app.filterBuffer)
+}
+
+// currentVisibleList returns whichever visible list corresponds to the
+// pane the cursor is currently in.
+func (app *App) currentVisibleList() []Task {
+	if app.activeView {
+		return app.visibleActiveList()
+	}
+	return app.visibleClosedList()
+}
+
+// clampCursor bounds app.cursor to the current pane's visible list so a
+// reload that shrank the list out from under the cursor (a background
+// ticker tick, an explicit refresh, or another instance's edit) can't leave
+// it pointing past the end.
+func (app *App) clampCursor() {
+	if n := len(app.currentVisibleList()); app.cursor >= n {
+		app.cursor = n - 1
+	}
+	if app.cursor < 0 {
+		app.cursor = 0
+	}
+}
+
+// loadHistory restores the undo stack from task_history so undo still
+// works after a restart.
+func (app *App) loadHistory() error {
+	rows, err := app.db.Query(`
+		SELECT id, task_id, operation, column_idx,
+			   prev_content, prev_spoc, prev_state, prev_priority, prev_due_date, prev_closed_at, prev_created_at
+		FROM task_history
+		ORDER BY id
+	`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	app.history = nil
+	for rows.Next() {
+		var e historyEntry
+		var column sql.NullInt64
+		var createdAt sql.NullString
+		err := rows.Scan(
+			&e.ID, &e.TaskID, &e.Operation, &column,
+			&e.PrevContent, &e.PrevSPOC, &e.PrevState, &e.PrevPriority, &e.PrevDueDate, &e.PrevClosedAt, &createdAt,
+		)
+		if err != nil {
+			return err
+		}
+		e.Column = int(column.Int64)
+		e.PrevCreatedAt = createdAt.String
+		app.history = append(app.history, e)
+	}
+	return nil
+}
+
+// recordHistory persists entry to task_history and pushes it onto the
+// undo stack, leaving the redo stack untouched.
+func (app *App) recordHistory(entry historyEntry) (historyEntry, error) {
+	res, err := app.db.Exec(
+		`INSERT INTO task_history
+		 (task_id, operation, column_idx, prev_content, prev_spoc, prev_state, prev_priority, prev_due_date, prev_closed_at, prev_created_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		entry.TaskID, entry.Operation, entry.Column,
+		entry.PrevContent, entry.PrevSPOC, entry.PrevState, entry.PrevPriority, entry.PrevDueDate, entry.PrevClosedAt, nullableString(entry.PrevCreatedAt),
+	)
+	if err != nil {
+		return entry, err
+	}
+	entry.ID, err = res.LastInsertId()
+	if err != nil {
+		return entry, err
+	}
+	app.history = append(app.history, entry)
+	return entry, nil
+}
+
+// pushHistory records entry as a brand new user action. A fresh edit or
+// delete makes any pending redo stale, so it clears the redo stack.
+func (app *App) pushHistory(entry historyEntry) error {
+	if _, err := app.recordHistory(entry); err != nil {
+		return err
+	}
+	app.redoStack = nil
+	return nil
+}
+
+// revertEdit sets entry.TaskID's entry.Column back to entry's recorded
+// value and returns the reciprocal entry — the value it just overwrote —
+// so the caller can push it onto the opposite stack.
+func (app *App) revertEdit(entry historyEntry) (historyEntry, error) {
+	var selectQuery, updateQuery string
+	switch entry.Column {
+	case 1:
+		selectQuery = "SELECT task_content FROM task WHERE id = ?"
+		updateQuery = "UPDATE task SET task_content = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?"
+	case 2:
+		selectQuery = "SELECT task_spoc FROM task WHERE id = ?"
+		updateQuery = "UPDATE task SET task_spoc = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?"
+	case 3:
+		selectQuery = "SELECT task_state FROM task WHERE id = ?"
+		updateQuery = "UPDATE task SET task_state = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?"
+	case 4:
+		selectQuery = "SELECT task_priority FROM task WHERE id = ?"
+		updateQuery = "UPDATE task SET task_priority = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?"
+	case 5:
+		selectQuery = "SELECT task_due_date FROM task WHERE id = ?"
+		updateQuery = "UPDATE task SET task_due_date = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?"
+	default:
+		return historyEntry{}, fmt.Errorf("unknown history column: %d", entry.Column)
+	}
+
+	var current sql.NullString
+	if err := app.db.QueryRow(selectQuery, entry.TaskID).Scan(&current); err != nil {
+		return historyEntry{}, err
+	}
+
+	// closed_at tracks task_state rather than being edited directly, so a
+	// column-3 revert needs the closed_at that was in effect before this
+	// undo/redo, too.
+	var currentClosedAt sql.NullString
+	if entry.Column == 3 {
+		if err := app.db.QueryRow("SELECT closed_at FROM task WHERE id = ?", entry.TaskID).Scan(&currentClosedAt); err != nil {
+			return historyEntry{}, err
+		}
+	}
+
+	var value interface{}
+	switch entry.Column {
+	case 1:
+		value = entry.PrevContent
+	case 2:
+		value = entry.PrevSPOC
+	case 3:
+		value = entry.PrevState
+	case 4:
+		value = entry.PrevPriority
+	case 5:
+		value = entry.PrevDueDate
+	}
+	if _, err := app.db.Exec(updateQuery, value, entry.TaskID); err != nil {
+		return historyEntry{}, err
+	}
+
+	if entry.Column == 3 {
+		if entry.PrevState == "closed" {
+			if _, err := app.db.Exec("UPDATE task SET closed_at = ? WHERE id = ?", entry.PrevClosedAt, entry.TaskID); err != nil {
+				return historyEntry{}, err
+			}
+		} else if _, err := app.db.Exec("UPDATE task SET closed_at = NULL WHERE id = ?", entry.TaskID); err != nil {
+			return historyEntry{}, err
+		}
+	}
+
+	reciprocal := historyEntry{TaskID: entry.TaskID, Operation: "edit", Column: entry.Column}
+	switch entry.Column {
+	case 1:
+		reciprocal.PrevContent = current.String
+	case 2:
+		reciprocal.PrevSPOC = current.String
+	case 3:
+		reciprocal.PrevState = current.String
+		reciprocal.PrevClosedAt = currentClosedAt
+	case 4:
+		reciprocal.PrevPriority = current.String
+	case 5:
+		reciprocal.PrevDueDate = current
+	}
+	return reciprocal, nil
+}
+
+// undo reverts the most recent history entry and pushes its reciprocal
+// onto the redo stack.
+func (app *App) undo() error {
+	if len(app.history) == 0 {
+		return nil
+	}
+	entry := app.history[len(app.history)-1]
+	app.history = app.history[:len(app.history)-1]
+
+	var reciprocal historyEntry
+	switch entry.Operation {
+	case "edit":
+		r, err := app.revertEdit(entry)
+		if err != nil {
+			return err
+		}
+		reciprocal = r
+	case "delete":
+		_, err := app.db.Exec(
+			`INSERT INTO task (id, task_content, task_spoc, task_state, task_priority, task_due_date, created_at, closed_at)
+			 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			entry.TaskID, entry.PrevContent, entry.PrevSPOC, entry.PrevState, entry.PrevPriority, entry.PrevDueDate,
+			entry.PrevCreatedAt, entry.PrevClosedAt,
+		)
+		if err != nil {
+			return err
+		}
+		// The reciprocal of restoring a deleted row is deleting it again.
+		reciprocal = historyEntry{
+			TaskID: entry.TaskID, Operation: "delete",
+			PrevContent: entry.PrevContent, PrevSPOC: entry.PrevSPOC,
+			PrevState: entry.PrevState, PrevPriority: entry.PrevPriority, PrevDueDate: entry.PrevDueDate,
+			PrevClosedAt: entry.PrevClosedAt, PrevCreatedAt: entry.PrevCreatedAt,
+		}
+	}
+
+	if _, err := app.db.Exec("DELETE FROM task_history WHERE id = ?", entry.ID); err != nil {
+		return err
+	}
+	app.redoStack = append(app.redoStack, reciprocal)
+	app.requestRefresh()
+	return nil
+}
+
+// redo reapplies the most recently undone entry and records its
+// reciprocal back onto the undo stack.
+func (app *App) redo() error {
+	if len(app.redoStack) == 0 {
+		return nil
+	}
+	entry := app.redoStack[len(app.redoStack)-1]
+	app.redoStack = app.redoStack[:len(app.redoStack)-1]
+
+	var reciprocal historyEntry
+	switch entry.Operation {
+	case "edit":
+		r, err := app.revertEdit(entry)
+		if err != nil {
+			return err
+		}
+		reciprocal = r
+	case "delete":
+		if _, err := app.db.Exec("DELETE FROM task WHERE id = ?", entry.TaskID); err != nil {
+			return err
+		}
+		reciprocal = historyEntry{
+			TaskID: entry.TaskID, Operation: "delete",
+			PrevContent: entry.PrevContent, PrevSPOC: entry.PrevSPOC,
+			PrevState: entry.PrevState, PrevPriority: entry.PrevPriority, PrevDueDate: entry.PrevDueDate,
+			PrevClosedAt: entry.PrevClosedAt, PrevCreatedAt: entry.PrevCreatedAt,
+		}
+	}
+
+	if _, err := app.recordHistory(reciprocal); err != nil {
+		return err
+	}
+	app.requestRefresh()
+	return nil
+}
+
 func (app *App) loadTasks() error {
 	// Load active tasks
 	activeRows, err := app.db.Query(`
-		SELECT id, task_content, task_spoc, task_state, 
-			   created_at, closed_at 
-		FROM task 
+		SELECT id, task_content, task_spoc, task_state,
+			   task_priority, task_due_date, created_at, closed_at
+		FROM task
 		WHERE task_state IN ('open', 'in_progress')
 		ORDER BY id
 	`)
@@ -102,9 +489,9 @@ func (app *App) loadTasks() error {
 
 	// Load closed tasks
 	closedRows, err := app.db.Query(`
-		SELECT id, task_content, task_spoc, task_state, 
-			   created_at, closed_at 
-		FROM task 
+		SELECT id, task_content, task_spoc, task_state,
+			   task_priority, task_due_date, created_at, closed_at
+		FROM task
 		WHERE task_state IN ('closed', 'cancelled')
 		ORDER BY id
 	`)
@@ -149,6 +536,8 @@ func (app *App) loadTasks() error {
 			&task.Content,
 			&task.SPOC,
 			&task.State,
+			&task.Priority,
+			&task.DueDate,
 			&task.Created,
 			&task.Closed,
 		)
@@ -166,6 +555,8 @@ func (app *App) loadTasks() error {
 			&task.Content,
 			&task.SPOC,
 			&task.State,
+			&task.Priority,
+			&task.DueDate,
 			&task.Created,
 			&task.Closed,
 		)
@@ -208,10 +599,10 @@ func (app *App) drawScreen() {
 	app.drawText(halfWidth+2, halfHeight+1, "Statistics", headerStyle)
 
 	// Draw active tasks
-	app.drawTaskList(1, 2, halfWidth-2, app.activeList, app.activeView)
+	app.drawTaskList(1, 2, halfWidth-2, app.visibleActiveList(), app.activeView)
 
 	// Draw completed tasks
-	app.drawTaskList(halfWidth+1, 2, width-halfWidth-2, app.closedList, !app.activeView)
+	app.drawTaskList(halfWidth+1, 2, width-halfWidth-2, app.visibleClosedList(), !app.activeView)
 
 	// Draw statistics
 	statsY := halfHeight + 2
@@ -221,11 +612,36 @@ func (app *App) drawScreen() {
 	app.drawText(halfWidth+2, statsY+3, fmt.Sprintf("Cancelled: %d", app.stats.totalCancelled), style)
 	app.drawText(halfWidth+2, statsY+4, fmt.Sprintf("Avg Completion Time: %.1f days", app.stats.avgCompletionTime), style)
 
-	// Update instructions based on which pane is active
-	if app.activeView {
-		app.drawText(1, height-1, "↑/↓: Move cursor | Tab: Switch view | h: Hide | j: Next | k: Previous | i: Edit | q: Quit", style)
-	} else {
-		app.drawText(1, height-1, "↑/↓: Move cursor | Tab: Switch view | h: Hide | j: Next | k: Previous | i: Edit | q: Quit", style)
+	// Update instructions based on which pane is active. While a filter is
+	// active, 'n'/'N' jump between matches instead of opening the new-task
+	// modal, so the hint reflects that instead of advertising a dead key.
+	newTaskHint := "n: New"
+	if app.filterBuffer != "" {
+		newTaskHint = "n/N: Next/Prev match"
+	}
+	instructions := fmt.Sprintf("↑/↓: Move cursor | Tab: Switch view | h: Hide | j: Next | k: Previous | i: Edit | %s | d: Delete | /: Search | u: Undo | q: Quit", newTaskHint)
+	app.drawText(1, height-1, instructions, style)
+
+	if len(app.history) > 0 {
+		hint := "Undo available (u)"
+		app.drawText(width-len(hint)-1, height-1, hint, style)
+	}
+
+	// Draw the search/filter status line just above the instructions.
+	// A pending validation error takes priority over the filter display.
+	errStyle := style.Foreground(tcell.ColorRed)
+	if app.mode == "search" {
+		app.drawText(1, height-2, "/"+app.filterBuffer, style)
+	} else if app.statusMsg != "" {
+		app.drawText(1, height-2, app.statusMsg, errStyle)
+	} else if app.filterBuffer != "" {
+		app.drawText(1, height-2, fmt.Sprintf("Filter: %s (n/N: jump, Esc: clear)", app.filterBuffer), style)
+	}
+
+	if app.mode == "new_task" {
+		app.drawNewTaskForm(width, height)
+	} else if app.mode == "confirm_delete" {
+		app.drawDeleteConfirm(width, height)
 	}
 }
 
@@ -233,8 +649,8 @@ func (app *App) drawTaskList(x, y, width int, tasks []Task, isActive bool) {
 	style := tcell.StyleDefault
 
 	// Draw header
-	headers := []string{"ID", "Content", "SPOC", "State"}
-	headerWidths := []int{4, width - 35, 15, 11}
+	headers := []string{"ID", "Content", "SPOC", "State", "Priority", "Due"}
+	headerWidths := []int{4, width - 55, 15, 11, 8, 10}
 
 	currentX := x
 	for i, header := range headers {
@@ -258,6 +674,8 @@ func (app *App) drawTaskList(x, y, width int, tasks []Task, isActive bool) {
 			task.Content,
 			task.SPOC,
 			task.State,
+			task.Priority,
+			task.DueDate.String,
 		}
 
 		for colIdx, field := range fields {
@@ -287,14 +705,32 @@ func (app *App) editCurrentTask(task Task) error {
 	return nil
 }
 
+// editBufferForCol returns the current value of the given task column so
+// Tab can cycle through it while in edit mode.
+func editBufferForCol(task Task, col int) string {
+	switch col {
+	case 1:
+		return task.Content
+	case 2:
+		return task.SPOC
+	case 3:
+		return task.State
+	case 4:
+		return task.Priority
+	case 5:
+		return task.DueDate.String
+	}
+	return ""
+}
+
 func (app *App) saveCurrentEdit() error {
 	var currentTask Task
 
 	// Get the correct task based on which view is active
-	if app.activeView && len(app.activeList) > 0 {
-		currentTask = app.activeList[app.cursor]
-	} else if !app.activeView && len(app.closedList) > 0 {
-		currentTask = app.closedList[app.cursor]
+	if app.activeView && len(app.visibleActiveList()) > 0 {
+		currentTask = app.visibleActiveList()[app.cursor]
+	} else if !app.activeView && len(app.visibleClosedList()) > 0 {
+		currentTask = app.visibleClosedList()[app.cursor]
 	} else {
 		return nil
 	}
@@ -306,6 +742,24 @@ func (app *App) saveCurrentEdit() error {
 	case 1:
 		query = "UPDATE task SET task_content = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?"
 		params = []interface{}{app.editBuffer, currentTask.ID}
+	case 2:
+		query = "UPDATE task SET task_spoc = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?"
+		params = []interface{}{app.editBuffer, currentTask.ID}
+	case 4:
+		validPriorities := map[string]bool{
+			"low": true, "med": true, "high": true, "urgent": true,
+		}
+		if !validPriorities[app.editBuffer] {
+			return fmt.Errorf("invalid priority: %s", app.editBuffer)
+		}
+		query = "UPDATE task SET task_priority = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?"
+		params = []interface{}{app.editBuffer, currentTask.ID}
+	case 5:
+		if !isValidDueDate(app.editBuffer) {
+			return fmt.Errorf("invalid due date (want %s): %s", dueDateLayout, app.editBuffer)
+		}
+		query = "UPDATE task SET task_due_date = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?"
+		params = []interface{}{nullableString(app.editBuffer), currentTask.ID}
 	case 3:
 		// Validate state
 		validStates := map[string]bool{
@@ -333,8 +787,28 @@ func (app *App) saveCurrentEdit() error {
 		return err
 	}
 
-	// Reload tasks to refresh the display
-	return app.loadTasks()
+	// Record the pre-edit value so this save can be undone
+	entry := historyEntry{TaskID: currentTask.ID, Operation: "edit", Column: app.editCol}
+	switch app.editCol {
+	case 1:
+		entry.PrevContent = currentTask.Content
+	case 2:
+		entry.PrevSPOC = currentTask.SPOC
+	case 3:
+		entry.PrevState = currentTask.State
+		entry.PrevClosedAt = currentTask.Closed
+	case 4:
+		entry.PrevPriority = currentTask.Priority
+	case 5:
+		entry.PrevDueDate = currentTask.DueDate
+	}
+	if err := app.pushHistory(entry); err != nil {
+		return err
+	}
+
+	// Ask the Run loop to reload tasks on its next iteration
+	app.requestRefresh()
+	return nil
 }
 
 func (app *App) Run() error {
@@ -343,91 +817,249 @@ func (app *App) Run() error {
 	if err := app.loadTasks(); err != nil {
 		return err
 	}
+	if err := app.loadHistory(); err != nil {
+		return err
+	}
+
+	// PollEvent blocks, so it runs on its own goroutine and feeds events
+	// back to the select loop below. This lets the loop also react to the
+	// refresh ticker and explicit refresh requests without freezing the UI.
+	events := make(chan tcell.Event)
+	go func() {
+		for {
+			events <- app.screen.PollEvent()
+		}
+	}()
+
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
 
 	for {
 		app.screen.Clear()
 		app.drawScreen()
 		app.screen.Show()
 
-		switch ev := app.screen.PollEvent().(type) {
-		case *tcell.EventKey:
-			if app.mode == "normal" {
-				switch ev.Key() {
-				case tcell.KeyEscape, tcell.KeyCtrlC:
-					return nil
-				case tcell.KeyTab:
-					app.activeView = !app.activeView
-					app.cursor = 0
-				case tcell.KeyRune:
-					switch ev.Rune() {
-					case 'q':
-						return nil
-					case 'h':
-						if app.activeView {
-							app.activeView = false
+		select {
+		case <-ticker.C:
+			if err := app.loadTasks(); err != nil {
+				return err
+			}
+			app.clampCursor()
+			continue
+		case <-app.refreshChan:
+			if err := app.loadTasks(); err != nil {
+				return err
+			}
+			app.clampCursor()
+			continue
+		case ev := <-events:
+			switch ev := ev.(type) {
+			case *tcell.EventKey:
+				if app.mode == "normal" {
+					switch ev.Key() {
+					case tcell.KeyEscape:
+						// Clear an active filter before quitting on Esc
+						if app.filterBuffer != "" {
+							app.filterBuffer = ""
 							app.cursor = 0
+						} else {
+							return nil
 						}
-					case 'l':
-						if !app.activeView {
-							app.activeView = true
-							app.cursor = 0
+					case tcell.KeyCtrlC:
+						return nil
+					case tcell.KeyTab:
+						app.activeView = !app.activeView
+						app.cursor = 0
+					case tcell.KeyCtrlR:
+						if err := app.redo(); err != nil {
+							return err
 						}
-					case 'j':
-						if app.activeView {
-							if app.cursor < len(app.activeList)-1 {
-								app.cursor++
+					case tcell.KeyRune:
+						switch ev.Rune() {
+						case 'q':
+							return nil
+						case 'u':
+							if err := app.undo(); err != nil {
+								return err
 							}
-						} else {
-							if app.cursor < len(app.closedList)-1 {
-								app.cursor++
+						case 'h':
+							if app.activeView {
+								app.activeView = false
+								app.cursor = 0
 							}
-						}
-					case 'k':
-						if app.cursor > 0 {
-							app.cursor--
-						}
-					case 'i':
-						// Only enter edit mode in active (left) pane
-						if app.activeView && len(app.activeList) > 0 {
-							app.mode = "edit"
-							app.editCol = 1 // Start with content
-							app.editBuffer = app.activeList[app.cursor].Content
+						case 'l':
+							if !app.activeView {
+								app.activeView = true
+								app.cursor = 0
+							}
+						case 'j':
+							if app.activeView {
+								if app.cursor < len(app.visibleActiveList())-1 {
+									app.cursor++
+								}
+							} else {
+								if app.cursor < len(app.visibleClosedList())-1 {
+									app.cursor++
+								}
+							}
+						case 'k':
+							if app.cursor > 0 {
+								app.cursor--
+							}
+						case 'i':
+							// Only enter edit mode in active (left) pane
+							if app.activeView && len(app.visibleActiveList()) > 0 {
+								app.mode = "edit"
+								app.editCol = 1 // Start with content
+								app.editBuffer = app.visibleActiveList()[app.cursor].Content
+								app.statusMsg = ""
+							}
+						case 'n':
+							// While a filter is active, 'n' jumps to the next
+							// match instead of opening the new-task modal
+							if app.filterBuffer != "" {
+								list := app.currentVisibleList()
+								if len(list) > 0 {
+									app.cursor = (app.cursor + 1) % len(list)
+								}
+							} else {
+								app.mode = "new_task"
+								app.newTaskFields = make([]string, 4)
+								app.newTaskFieldIdx = 0
+								app.statusMsg = ""
+							}
+						case 'N':
+							if app.filterBuffer != "" {
+								list := app.currentVisibleList()
+								if len(list) > 0 {
+									app.cursor = (app.cursor - 1 + len(list)) % len(list)
+								}
+							}
+						case 'd':
+							if app.activeView && len(app.visibleActiveList()) > 0 {
+								app.mode = "confirm_delete"
+								app.deleteTaskID = app.visibleActiveList()[app.cursor].ID
+							} else if !app.activeView && len(app.visibleClosedList()) > 0 {
+								app.mode = "confirm_delete"
+								app.deleteTaskID = app.visibleClosedList()[app.cursor].ID
+							}
+						case '/':
+							app.mode = "search"
+							app.filterBuffer = ""
+							app.cursor = 0
 						}
 					}
-				}
-			} else { // Edit mode
-				switch ev.Key() {
-				case tcell.KeyEscape:
-					app.mode = "normal"
-					app.editBuffer = ""
-				case tcell.KeyEnter:
-					if err := app.saveCurrentEdit(); err != nil {
+				} else if app.mode == "search" {
+					switch ev.Key() {
+					case tcell.KeyEscape:
+						app.mode = "normal"
+						app.filterBuffer = ""
+						app.cursor = 0
+					case tcell.KeyEnter:
 						app.mode = "normal"
-						return err
+						app.cursor = 0
+					case tcell.KeyBackspace, tcell.KeyBackspace2:
+						if len(app.filterBuffer) > 0 {
+							app.filterBuffer = app.filterBuffer[:len(app.filterBuffer)-1]
+						}
+						app.cursor = 0
+					case tcell.KeyRune:
+						app.filterBuffer += string(ev.Rune())
+						app.cursor = 0
 					}
-					app.mode = "normal"
-				case tcell.KeyTab:
-					// Toggle between content and state
-					if app.editCol == 1 {
-						app.editCol = 3
-						app.editBuffer = app.activeList[app.cursor].State
-					} else {
-						app.editCol = 1
-						app.editBuffer = app.activeList[app.cursor].Content
+				} else if app.mode == "confirm_delete" {
+					if ev.Key() == tcell.KeyRune {
+						switch ev.Rune() {
+						case 'y':
+							if err := app.deleteTask(app.deleteTaskID); err != nil {
+								app.mode = "normal"
+								return err
+							}
+							app.cursor = 0
+							app.mode = "normal"
+						case 'n':
+							app.mode = "normal"
+						}
+					} else if ev.Key() == tcell.KeyEscape {
+						app.mode = "normal"
 					}
-				case tcell.KeyBackspace, tcell.KeyBackspace2:
-					if len(app.editBuffer) > 0 {
-						app.editBuffer = app.editBuffer[:len(app.editBuffer)-1]
+				} else if app.mode == "new_task" {
+					switch ev.Key() {
+					case tcell.KeyEscape:
+						app.mode = "normal"
+						app.newTaskFields = nil
+						app.statusMsg = ""
+					case tcell.KeyEnter:
+						// A validation failure (bad priority, empty content)
+						// stays in the form with the message shown instead
+						// of tearing down the whole TUI.
+						if err := app.createTask(); err != nil {
+							app.statusMsg = err.Error()
+						} else {
+							app.mode = "normal"
+							app.statusMsg = ""
+						}
+					case tcell.KeyTab:
+						app.newTaskFieldIdx = (app.newTaskFieldIdx + 1) % len(app.newTaskFields)
+					case tcell.KeyBackspace, tcell.KeyBackspace2:
+						field := app.newTaskFields[app.newTaskFieldIdx]
+						if len(field) > 0 {
+							app.newTaskFields[app.newTaskFieldIdx] = field[:len(field)-1]
+						}
+					case tcell.KeyRune:
+						app.newTaskFields[app.newTaskFieldIdx] += string(ev.Rune())
 					}
-				case tcell.KeyRune:
-					if app.editCol == 3 {
-						// Validate state input
-						newState := app.editBuffer + string(ev.Rune())
-						if isValidStateInput(newState) {
+				} else { // Edit mode
+					switch ev.Key() {
+					case tcell.KeyEscape:
+						app.mode = "normal"
+						app.editBuffer = ""
+						app.statusMsg = ""
+					case tcell.KeyEnter:
+						// A validation failure (bad state/priority) stays in
+						// edit mode with the message shown instead of
+						// tearing down the whole TUI.
+						if err := app.saveCurrentEdit(); err != nil {
+							app.statusMsg = err.Error()
+						} else {
+							app.mode = "normal"
+							app.statusMsg = ""
+						}
+					case tcell.KeyTab:
+						// Cycle to the next mutable column. A background
+						// reload may have emptied the list out from under
+						// an open edit; bail back to normal mode instead
+						// of indexing into nothing.
+						list := app.visibleActiveList()
+						if len(list) == 0 || app.cursor >= len(list) {
+							app.mode = "normal"
+							app.editBuffer = ""
+							break
+						}
+						task := list[app.cursor]
+						idx := 0
+						for i, col := range editableCols {
+							if col == app.editCol {
+								idx = i
+								break
+							}
+						}
+						app.editCol = editableCols[(idx+1)%len(editableCols)]
+						app.editBuffer = editBufferForCol(task, app.editCol)
+					case tcell.KeyBackspace, tcell.KeyBackspace2:
+						if len(app.editBuffer) > 0 {
+							app.editBuffer = app.editBuffer[:len(app.editBuffer)-1]
+						}
+					case tcell.KeyRune:
+						if app.editCol == 3 {
+							// Validate state input
+							newState := app.editBuffer + string(ev.Rune())
+							if isValidStateInput(newState) {
+								app.editBuffer += string(ev.Rune())
+							}
+						} else {
 							app.editBuffer += string(ev.Rune())
 						}
-					} else {
-						app.editBuffer += string(ev.Rune())
 					}
 				}
 			}
@@ -435,6 +1067,111 @@ func (app *App) Run() error {
 	}
 }
 
+// nullableString converts an empty string to a SQL NULL so optional fields
+// like due date don't get persisted as empty strings.
+func nullableString(s string) sql.NullString {
+	if s == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: s, Valid: true}
+}
+
+// createTask inserts a task from the new-task modal's fields (content,
+// SPOC, priority, due date) and requests a reload of the lists.
+func (app *App) createTask() error {
+	content := app.newTaskFields[0]
+	if strings.TrimSpace(content) == "" {
+		return fmt.Errorf("content is required")
+	}
+	spoc := app.newTaskFields[1]
+
+	priority := app.newTaskFields[2]
+	if priority == "" {
+		priority = "med"
+	}
+	validPriorities := map[string]bool{
+		"low": true, "med": true, "high": true, "urgent": true,
+	}
+	if !validPriorities[priority] {
+		return fmt.Errorf("invalid priority: %s", priority)
+	}
+
+	dueDate := app.newTaskFields[3]
+	if !isValidDueDate(dueDate) {
+		return fmt.Errorf("invalid due date (want %s): %s", dueDateLayout, dueDate)
+	}
+
+	_, err := app.db.Exec(
+		`INSERT INTO task (task_content, task_spoc, task_state, task_priority, task_due_date)
+		 VALUES (?, ?, 'open', ?, ?)`,
+		content, spoc, priority, nullableString(dueDate),
+	)
+	if err != nil {
+		return err
+	}
+
+	app.newTaskFields = nil
+	app.requestRefresh()
+	return nil
+}
+
+// deleteTask removes a task by ID, records a history entry so the delete
+// can be undone, and requests a reload of the lists.
+func (app *App) deleteTask(id string) error {
+	var prev historyEntry
+	prev.TaskID = id
+	prev.Operation = "delete"
+	err := app.db.QueryRow(
+		`SELECT task_content, task_spoc, task_state, task_priority, task_due_date, created_at, closed_at
+		 FROM task WHERE id = ?`, id,
+	).Scan(&prev.PrevContent, &prev.PrevSPOC, &prev.PrevState, &prev.PrevPriority, &prev.PrevDueDate, &prev.PrevCreatedAt, &prev.PrevClosedAt)
+	if err != nil {
+		return err
+	}
+
+	if _, err := app.db.Exec("DELETE FROM task WHERE id = ?", id); err != nil {
+		return err
+	}
+
+	if err := app.pushHistory(prev); err != nil {
+		return err
+	}
+
+	app.requestRefresh()
+	return nil
+}
+
+// drawNewTaskForm renders the modal used to capture a new task's content,
+// SPOC, priority, and due date.
+func (app *App) drawNewTaskForm(width, height int) {
+	style := tcell.StyleDefault
+	labelStyle := style.Bold(true)
+	activeStyle := style.Background(tcell.ColorYellow).Foreground(tcell.ColorBlack)
+
+	formX, formY := width/2-20, height/2-4
+	labels := []string{"Content", "SPOC", "Priority", "Due Date"}
+
+	app.drawText(formX, formY, "New Task (Tab: next field, Enter: save, Esc: cancel)", labelStyle)
+	for i, label := range labels {
+		rowY := formY + i + 2
+		app.drawText(formX, rowY, label+":", labelStyle)
+
+		fieldStyle := style
+		if app.newTaskFieldIdx == i {
+			fieldStyle = activeStyle
+		}
+		app.drawText(formX+10, rowY, app.newTaskFields[i], fieldStyle)
+	}
+}
+
+// drawDeleteConfirm renders the y/n confirmation prompt shown before a
+// task is deleted.
+func (app *App) drawDeleteConfirm(width, height int) {
+	style := tcell.StyleDefault.Background(tcell.ColorRed)
+	msg := fmt.Sprintf("Delete task %s? (y/n)", app.deleteTaskID)
+	app.drawText(width/2-len(msg)/2, height/2, msg, style)
+}
+
 func isValidStateInput(s string) bool {
 	validStates := []string{"open", "in_progress", "closed", "cancelled"}
 	for _, state := range validStates {
@@ -445,6 +1182,19 @@ func isValidStateInput(s string) bool {
 	return false
 }
 
+// dueDateLayout is the date format accepted for the Due Date field.
+const dueDateLayout = "2006-01-02"
+
+// isValidDueDate reports whether s is empty (no due date) or a valid
+// dueDateLayout date.
+func isValidDueDate(s string) bool {
+	if s == "" {
+		return true
+	}
+	_, err := time.Parse(dueDateLayout, s)
+	return err == nil
+}
+
 func main() {
 	app, err := NewApp()
 	if err != nil {